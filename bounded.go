@@ -0,0 +1,330 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	ScopeN[T any]        func(ctx context.Context, state *T, maxConcurrency int, checker Checker, cleanup CleanUp[T], works ...Work[T])
+	WithCancelN[T any]   func(ctx context.Context, state *T, maxConcurrency int, checker Checker, cleanup CleanUp[T], works ...Work[T])
+	WithTimeoutN[T any]  func(ctx context.Context, state *T, maxConcurrency int, duration time.Duration, checker Checker, cleanup CleanUp[T], works ...Work[T])
+	WithDeadlineN[T any] func(ctx context.Context, state *T, maxConcurrency int, deadline time.Time, checker Checker, cleanup CleanUp[T], works ...Work[T])
+)
+
+// parallelN runs the given functions in parallel through a semaphore-style
+// worker pool, never running more than maxConcurrency of them at once. A
+// maxConcurrency <= 0 is treated as 1.
+func parallelN[T any](ctx context.Context, state *T, maxConcurrency int, f ...Work[T]) (success int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scope recovered: %v", r)
+		}
+	}()
+
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	var successCount atomic.Int64
+	errs := make([]error, len(f))
+
+	wg.Add(len(f))
+	for i, w := range f {
+		sem <- struct{}{}
+		go func(i int, w Work[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("scope recovered: %v", r)
+				}
+			}()
+
+			if err := w(ctx, state); err != nil {
+				errs[i] = err
+				return
+			}
+
+			successCount.Add(1)
+		}(i, w)
+	}
+	wg.Wait()
+
+	nilIdx := make([]int, 0, len(errs))
+	for i, err := range errs {
+		if err == nil {
+			nilIdx = append(nilIdx, i)
+		}
+	}
+
+	slices.Reverse(nilIdx)
+	success = int(successCount.Load())
+
+	for _, i := range nilIdx {
+		errs = append(errs[:i], errs[i+1:]...)
+	}
+
+	return success, errors.Join(errs...)
+}
+
+// SequenceN runs the given functions in sequence. maxConcurrency is accepted
+// for symmetry with AllN/AnyN, but a sequence never runs more than one Work
+// at a time regardless of its value.
+// If any of the functions returns an error, the error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceN[T any](ctx context.Context, state *T, maxConcurrency int, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	if err := sequence[T](ctx, state, f...); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ ScopeN[int] = SequenceN[int]
+
+// SequenceNWithCancel runs the given functions in sequence with a cancel function.
+// maxConcurrency is accepted for symmetry with AllNWithCancel/AnyNWithCancel.
+// If any of the functions returns an error, the error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceNWithCancel[T any](ctx context.Context, state *T, maxConcurrency int, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	if err := causeErr(ctx, sequence[T](ctx, state, f...)); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithCancelN[int] = SequenceNWithCancel[int]
+
+// SequenceNWithTimeout runs the given functions in sequence with a timeout.
+// maxConcurrency is accepted for symmetry with AllNWithTimeout/AnyNWithTimeout.
+// If any of the functions returns an error, the error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceNWithTimeout[T any](ctx context.Context, state *T, maxConcurrency int, timeout time.Duration, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	if err := causeErr(ctx, sequence[T](ctx, state, f...)); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithTimeoutN[int] = SequenceNWithTimeout[int]
+
+// SequenceNWithDeadline runs the given functions in sequence with a deadline.
+// maxConcurrency is accepted for symmetry with AllNWithDeadline/AnyNWithDeadline.
+// If any of the functions returns an error, the error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceNWithDeadline[T any](ctx context.Context, state *T, maxConcurrency int, deadline time.Time, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	if err := causeErr(ctx, sequence[T](ctx, state, f...)); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithDeadlineN[int] = SequenceNWithDeadline[int]
+
+// AllN runs the given functions in parallel, never more than maxConcurrency
+// of them at once.
+// If any of the functions returns an error, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllN[T any](ctx context.Context, state *T, maxConcurrency int, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	if _, err := parallelN[T](ctx, state, maxConcurrency, f...); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ ScopeN[int] = AllN[int]
+
+// AllNWithCancel runs the given functions in parallel with a cancel function,
+// never more than maxConcurrency of them at once.
+// If any of the functions returns an error, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllNWithCancel[T any](ctx context.Context, state *T, maxConcurrency int, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	_, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if err := causeErr(ctx, err); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithCancelN[int] = AllNWithCancel[int]
+
+// AllNWithTimeout runs the given functions in parallel with a timeout, never
+// more than maxConcurrency of them at once.
+// If any of the functions returns an error, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllNWithTimeout[T any](ctx context.Context, state *T, maxConcurrency int, timeout time.Duration, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	_, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if err := causeErr(ctx, err); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithTimeoutN[int] = AllNWithTimeout[int]
+
+// AllNWithDeadline runs the given functions in parallel with a deadline,
+// never more than maxConcurrency of them at once.
+// If any of the functions returns an error, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllNWithDeadline[T any](ctx context.Context, state *T, maxConcurrency int, deadline time.Time, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	_, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if err := causeErr(ctx, err); err != nil {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithDeadlineN[int] = AllNWithDeadline[int]
+
+// AnyN runs the given functions in parallel, never more than maxConcurrency
+// of them at once.
+// If all of the functions fail, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyN[T any](ctx context.Context, state *T, maxConcurrency int, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	success, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if success == 0 {
+		errChecker(err)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ ScopeN[int] = AnyN[int]
+
+// AnyNWithCancel runs the given functions in parallel with a cancel function,
+// never more than maxConcurrency of them at once.
+// If all of the functions fail, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyNWithCancel[T any](ctx context.Context, state *T, maxConcurrency int, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	success, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if success == 0 {
+		errChecker(causeErr(ctx, err))
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithCancelN[int] = AnyNWithCancel[int]
+
+// AnyNWithTimeout runs the given functions in parallel with a timeout, never
+// more than maxConcurrency of them at once.
+// If all of the functions fail, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyNWithTimeout[T any](ctx context.Context, state *T, maxConcurrency int, timeout time.Duration, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	success, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if success == 0 {
+		errChecker(causeErr(ctx, err))
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithTimeoutN[int] = AnyNWithTimeout[int]
+
+// AnyNWithDeadline runs the given functions in parallel with a deadline,
+// never more than maxConcurrency of them at once.
+// If all of the functions fail, the joined error is passed to the errChecker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyNWithDeadline[T any](ctx context.Context, state *T, maxConcurrency int, deadline time.Time, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	success, err := parallelN[T](ctx, state, maxConcurrency, f...)
+	if success == 0 {
+		errChecker(causeErr(ctx, err))
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithDeadlineN[int] = AnyNWithDeadline[int]
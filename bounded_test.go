@@ -0,0 +1,83 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAllNBoundsConcurrency(t *testing.T) {
+	const (
+		totalWorks     = 20
+		maxConcurrency = 4
+	)
+
+	var current, peak atomic.Int64
+	works := make([]Work[int], totalWorks)
+	for i := range works {
+		works[i] = func(ctx context.Context, state *int) error {
+			n := current.Add(1)
+			defer current.Add(-1)
+
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		}
+	}
+
+	var checkerErr error
+	AllN(context.Background(), new(int), maxConcurrency, func(err error) {
+		checkerErr = err
+	}, nil, works...)
+
+	if checkerErr != nil {
+		t.Fatalf("unexpected error: %v", checkerErr)
+	}
+	if got := peak.Load(); got > maxConcurrency {
+		t.Fatalf("observed concurrency %d exceeds max %d", got, maxConcurrency)
+	}
+}
+
+func TestAllNWorksCompleteBeforeCheckerAndCleanup(t *testing.T) {
+	const totalWorks = 10
+
+	var completed atomic.Int64
+	works := make([]Work[int], totalWorks)
+	for i := range works {
+		works[i] = func(ctx context.Context, state *int) error {
+			time.Sleep(time.Millisecond)
+			completed.Add(1)
+			return nil
+		}
+	}
+	works[totalWorks-1] = func(ctx context.Context, state *int) error {
+		time.Sleep(time.Millisecond)
+		completed.Add(1)
+		return errors.New("boom")
+	}
+
+	checkerRan, cleanupRan := false, false
+	AllN(context.Background(), new(int), 3, func(err error) {
+		checkerRan = true
+		if got := completed.Load(); got != totalWorks {
+			t.Errorf("checker ran with only %d/%d works complete", got, totalWorks)
+		}
+	}, func(ctx context.Context, state *int) {
+		cleanupRan = true
+		if got := completed.Load(); got != totalWorks {
+			t.Errorf("cleanup ran with only %d/%d works complete", got, totalWorks)
+		}
+	}, works...)
+
+	if !checkerRan || !cleanupRan {
+		t.Fatalf("expected both checker and cleanup to run, checker=%v cleanup=%v", checkerRan, cleanupRan)
+	}
+}
@@ -0,0 +1,74 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errCancelTestBoom = errors.New("boom")
+
+func TestSequenceWithTimeoutSurfacesCause(t *testing.T) {
+	var gotErr error
+	SequenceWithTimeout(context.Background(), new(int), 20*time.Millisecond, func(err error) {
+		gotErr = err
+	}, nil, func(ctx context.Context, state *int) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if !errors.Is(gotErr, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded in the joined error, got %v", gotErr)
+	}
+}
+
+func TestCancelSurfacesDomainCause(t *testing.T) {
+	var gotErr error
+	SequenceWithCancel(context.Background(), new(int), func(err error) {
+		gotErr = err
+	}, nil,
+		func(ctx context.Context, state *int) error {
+			Cancel(ctx, errCancelTestBoom)
+			return nil
+		},
+		func(ctx context.Context, state *int) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+
+	if !errors.Is(gotErr, errCancelTestBoom) {
+		t.Fatalf("expected errCancelTestBoom in the joined error, got %v", gotErr)
+	}
+}
+
+// TestNestedScopeCausePropagation checks that a scope nested inside another
+// scope's Work observes the outer scope's cancellation cause through
+// context.Cause, even though the inner scope derives its own child context.
+func TestNestedScopeCausePropagation(t *testing.T) {
+	var innerErr error
+
+	outerWork := func(ctx context.Context, state *int) error {
+		SequenceWithCancel(ctx, state, func(err error) {
+			innerErr = err
+		}, nil, func(ctx context.Context, state *int) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		return context.Cause(ctx)
+	}
+
+	var outerErr error
+	AllWithTimeout(context.Background(), new(int), 20*time.Millisecond, func(err error) {
+		outerErr = err
+	}, nil, outerWork)
+
+	if !errors.Is(outerErr, context.DeadlineExceeded) {
+		t.Fatalf("expected outer scope to report context.DeadlineExceeded, got %v", outerErr)
+	}
+	if !errors.Is(innerErr, context.DeadlineExceeded) {
+		t.Fatalf("expected nested scope to observe the outer deadline, got %v", innerErr)
+	}
+}
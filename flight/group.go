@@ -0,0 +1,167 @@
+// Package flight coalesces concurrent, idempotent Works sharing the same key
+// onto a single in-flight execution, in the style of buildkit's
+// flightcontrol package.
+package flight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/snowmerak/scope"
+)
+
+// ErrRetry is a sentinel a Work can return to ask Group.Do to re-execute it
+// after a backoff, instead of failing the call outright.
+var ErrRetry = errors.New("flight: retry")
+
+var errRetryTimeout = errors.New("flight: retry timeout")
+
+const (
+	retryStart = time.Millisecond
+	retryCap   = 3 * time.Second
+)
+
+// Group coalesces concurrent Do calls for the same key onto a single
+// execution of the associated Work, sharing its result with every waiter.
+// The zero value is a valid, empty Group.
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+type call[T any] struct {
+	cancel  context.CancelCauseFunc
+	done    chan struct{}
+	err     error
+	waiters int
+}
+
+// Do runs work under key, coalescing it with any other in-flight Do call for
+// the same key on g so that only one execution of work happens at a time per
+// key; every caller shares its result. The shared execution's context is
+// tracked independently of any single caller's ctx and is canceled only once
+// every waiter for key has gone away, so one caller's ctx being canceled does
+// not stop the work for the others still waiting on it. If ctx is canceled
+// before the shared execution finishes, Do returns context.Cause(ctx) without
+// waiting for the result.
+func (g *Group[T]) Do(ctx context.Context, state *T, key string, work scope.Work[T]) error {
+	g.mu.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		c = g.newCall(key, state, work)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// Retire this call from the map in the same critical section as
+			// the waiters hitting zero, so a Do racing in right behind us
+			// can never attach to a call we've just decided to tear down.
+			g.forget(key, c)
+			c.cancel(context.Canceled)
+		}
+		g.mu.Unlock()
+	}()
+
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func (g *Group[T]) newCall(key string, state *T, work scope.Work[T]) *call[T] {
+	if g.calls == nil {
+		g.calls = make(map[string]*call[T])
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	c := &call[T]{cancel: cancel, done: make(chan struct{})}
+	g.calls[key] = c
+
+	go func() {
+		c.err = runWithRetry(ctx, state, work)
+		close(c.done)
+
+		g.mu.Lock()
+		g.forget(key, c)
+		g.mu.Unlock()
+	}()
+
+	return c
+}
+
+// forget removes c from g.calls under key, but only if it's still the call
+// registered there — guarding against clobbering a newer call that already
+// replaced it. Callers must hold g.mu.
+func (g *Group[T]) forget(key string, c *call[T]) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
+
+// runWithRetry runs work, recovering a panic into a "scope recovered:" error
+// consistent with scope.Sequence/scope.All. While work keeps returning
+// ErrRetry it is re-run after an exponential backoff starting at 1ms and
+// doubling up to a 3s cap; once the next backoff would exceed the cap,
+// runWithRetry gives up and returns errRetryTimeout wrapping the last error.
+func runWithRetry[T any](ctx context.Context, state *T, work scope.Work[T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scope recovered: %v", r)
+		}
+	}()
+
+	backoff := retryStart
+	for {
+		err = work(ctx, state)
+		if !errors.Is(err, ErrRetry) {
+			return err
+		}
+
+		if backoff > retryCap {
+			return fmt.Errorf("%w: %w", errRetryTimeout, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// FlightSequence runs works in sequence like scope.Sequence, but routes each
+// Work through g.Do first, keyed by keyer(state), so that a Work already
+// in flight for that key is coalesced instead of re-executed.
+func FlightSequence[T any](ctx context.Context, state *T, g *Group[T], keyer func(*T) string, errChecker scope.Checker, cleanup scope.CleanUp[T], works ...scope.Work[T]) {
+	scope.Sequence(ctx, state, errChecker, cleanup, wrapWorks(g, keyer, works)...)
+}
+
+// FlightAll runs works in parallel like scope.All, but routes each Work
+// through g.Do first, keyed by keyer(state), so that a Work already in
+// flight for that key is coalesced instead of re-executed.
+func FlightAll[T any](ctx context.Context, state *T, g *Group[T], keyer func(*T) string, errChecker scope.Checker, cleanup scope.CleanUp[T], works ...scope.Work[T]) {
+	scope.All(ctx, state, errChecker, cleanup, wrapWorks(g, keyer, works)...)
+}
+
+func wrapWorks[T any](g *Group[T], keyer func(*T) string, works []scope.Work[T]) []scope.Work[T] {
+	wrapped := make([]scope.Work[T], len(works))
+	for i, w := range works {
+		w := w
+		wrapped[i] = func(ctx context.Context, state *T) error {
+			return g.Do(ctx, state, keyer(state), w)
+		}
+	}
+
+	return wrapped
+}
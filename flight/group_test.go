@@ -0,0 +1,138 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupDoDedupesConcurrentCallers(t *testing.T) {
+	var g Group[int]
+	var calls atomic.Int64
+
+	work := func(ctx context.Context, state *int) error {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = g.Do(context.Background(), new(int), "key", work)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected work to run exactly once, ran %d times", got)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestGroupDoCancelOneKeepsOthersRunning(t *testing.T) {
+	var g Group[int]
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	work := func(ctx context.Context, state *int) error {
+		close(started)
+		<-release
+		return nil
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	errCh1 := make(chan error, 1)
+	go func() { errCh1 <- g.Do(ctx1, new(int), "key", work) }()
+
+	<-started
+
+	errCh2 := make(chan error, 1)
+	go func() { errCh2 <- g.Do(context.Background(), new(int), "key", work) }()
+
+	// Wait for caller 2 to have actually joined the in-flight call as a
+	// second waiter before canceling caller 1, so caller 1's teardown never
+	// races caller 2's join.
+	waitForWaiters(t, &g, "key", 2)
+	cancel1()
+
+	if err1 := <-errCh1; !errors.Is(err1, context.Canceled) {
+		t.Fatalf("expected caller 1 to observe context.Canceled, got %v", err1)
+	}
+
+	select {
+	case err2 := <-errCh2:
+		t.Fatalf("caller 2 returned early with %v; work should still be in flight for it", err2)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	if err2 := <-errCh2; err2 != nil {
+		t.Fatalf("caller 2: unexpected error %v", err2)
+	}
+}
+
+// waitForWaiters polls g's internal call tracking for key until it has at
+// least want waiters attached, so callers don't have to guess a sleep long
+// enough for a goroutine to have reached g.Do's waiters++.
+func waitForWaiters[T any](t *testing.T, g *Group[T], key string, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		c, ok := g.calls[key]
+		waiters := 0
+		if ok {
+			waiters = c.waiters
+		}
+		g.mu.Unlock()
+
+		if ok && waiters >= want {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d waiters on key %q", want, key)
+}
+
+func TestGroupDoPanicFansOutToAllWaiters(t *testing.T) {
+	var g Group[int]
+
+	work := func(ctx context.Context, state *int) error {
+		panic("boom")
+	}
+
+	const n = 3
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = g.Do(context.Background(), new(int), "key", work)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "scope recovered:") {
+			t.Fatalf("caller %d: expected a \"scope recovered:\" error, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	Work2[T, R any] func(ctx context.Context, state *T) (R, error)
+	CheckerR[R any] func(results []R, err error)
+
+	ScopeR[T, R any]        func(ctx context.Context, state *T, checker CheckerR[R], cleanup CleanUp[T], works ...Work2[T, R])
+	WithCancelR[T, R any]   func(ctx context.Context, state *T, checker CheckerR[R], cleanup CleanUp[T], works ...Work2[T, R])
+	WithTimeoutR[T, R any]  func(ctx context.Context, state *T, duration time.Duration, checker CheckerR[R], cleanup CleanUp[T], works ...Work2[T, R])
+	WithDeadlineR[T, R any] func(ctx context.Context, state *T, deadline time.Time, checker CheckerR[R], cleanup CleanUp[T], works ...Work2[T, R])
+)
+
+// ErrAnyWon is the cancellation cause AnyR (and its Cancel/Timeout/Deadline
+// siblings) set on the remaining Works once one of them has already
+// succeeded.
+var ErrAnyWon = errors.New("scope: any succeeded first")
+
+// sequenceR runs the given functions in sequence, collecting the result of
+// each in submission order. If any of the functions returns an error, the
+// results gathered so far and that error are returned.
+func sequenceR[T, R any](ctx context.Context, state *T, f ...Work2[T, R]) (results []R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scope recovered: %v", r)
+		}
+	}()
+
+	results = make([]R, 0, len(f))
+	for _, w := range f {
+		res, werr := w(ctx, state)
+		if werr != nil {
+			return results, werr
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// parallelR runs the given functions in parallel, collecting the results of
+// the successful ones in submission order.
+func parallelR[T, R any](ctx context.Context, state *T, f ...Work2[T, R]) (results []R, success int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scope recovered: %v", r)
+		}
+	}()
+
+	rs := make([]R, len(f))
+	ok := make([]bool, len(f))
+	errs := make([]error, len(f))
+
+	var wg sync.WaitGroup
+	wg.Add(len(f))
+	for i, w := range f {
+		go func(i int, w Work2[T, R]) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("scope recovered: %v", r)
+				}
+			}()
+
+			res, werr := w(ctx, state)
+			if werr != nil {
+				errs[i] = werr
+				return
+			}
+
+			rs[i] = res
+			ok[i] = true
+		}(i, w)
+	}
+	wg.Wait()
+
+	results = make([]R, 0, len(f))
+	joined := make([]error, 0, len(f))
+	for i := range f {
+		if ok[i] {
+			results = append(results, rs[i])
+			continue
+		}
+		if errs[i] != nil {
+			joined = append(joined, errs[i])
+		}
+	}
+
+	return results, len(results), errors.Join(joined...)
+}
+
+// parallelAnyR runs the given functions in parallel, canceling the rest with
+// cause ErrAnyWon as soon as one succeeds. It still waits for every Work to
+// actually return before parallelAnyR itself returns — a losing Work only
+// learns of the cancellation cooperatively through ctx, so the caller must
+// not get state back while a loser might still be touching it.
+func parallelAnyR[T, R any](ctx context.Context, state *T, f ...Work2[T, R]) (result R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scope recovered: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	type outcome struct {
+		res R
+		err error
+	}
+
+	out := make(chan outcome, len(f))
+	var wg sync.WaitGroup
+	wg.Add(len(f))
+	for _, w := range f {
+		go func(w Work2[T, R]) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					out <- outcome{err: fmt.Errorf("scope recovered: %v", r)}
+				}
+			}()
+
+			res, werr := w(ctx, state)
+			out <- outcome{res: res, err: werr}
+		}(w)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	var won bool
+	var errs []error
+	for o := range out {
+		if o.err == nil && !won {
+			won = true
+			result = o.res
+			cancel(ErrAnyWon)
+			continue
+		}
+
+		if o.err != nil {
+			errs = append(errs, o.err)
+		}
+	}
+
+	if won {
+		return result, nil
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// SequenceR runs the given functions in sequence, passing the results
+// collected so far and any error to the checker once the sequence stops.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceR[T, R any](ctx context.Context, state *T, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	results, err := sequenceR[T, R](ctx, state, f...)
+	checker(results, err)
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ ScopeR[int, int] = SequenceR[int, int]
+
+// SequenceRWithCancel runs the given functions in sequence with a cancel
+// function, passing the results collected so far and any error (joined with
+// the cancellation cause, if any) to the checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceRWithCancel[T, R any](ctx context.Context, state *T, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	results, err := sequenceR[T, R](ctx, state, f...)
+	checker(results, causeErr(ctx, err))
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithCancelR[int, int] = SequenceRWithCancel[int, int]
+
+// SequenceRWithTimeout runs the given functions in sequence with a timeout,
+// passing the results collected so far and any error (joined with the
+// cancellation cause, if any) to the checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceRWithTimeout[T, R any](ctx context.Context, state *T, timeout time.Duration, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	results, err := sequenceR[T, R](ctx, state, f...)
+	checker(results, causeErr(ctx, err))
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithTimeoutR[int, int] = SequenceRWithTimeout[int, int]
+
+// SequenceRWithDeadline runs the given functions in sequence with a
+// deadline, passing the results collected so far and any error (joined with
+// the cancellation cause, if any) to the checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func SequenceRWithDeadline[T, R any](ctx context.Context, state *T, deadline time.Time, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	results, err := sequenceR[T, R](ctx, state, f...)
+	checker(results, causeErr(ctx, err))
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithDeadlineR[int, int] = SequenceRWithDeadline[int, int]
+
+// AllR runs the given functions in parallel, passing the results of the
+// successful ones (in submission order) and the joined error of the failed
+// ones to the checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllR[T, R any](ctx context.Context, state *T, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	results, _, err := parallelR[T, R](ctx, state, f...)
+	checker(results, err)
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ ScopeR[int, int] = AllR[int, int]
+
+// AllRWithCancel runs the given functions in parallel with a cancel
+// function, passing the results of the successful ones (in submission
+// order) and the joined error (plus cancellation cause, if any) to the
+// checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllRWithCancel[T, R any](ctx context.Context, state *T, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	results, _, err := parallelR[T, R](ctx, state, f...)
+	checker(results, causeErr(ctx, err))
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithCancelR[int, int] = AllRWithCancel[int, int]
+
+// AllRWithTimeout runs the given functions in parallel with a timeout,
+// passing the results of the successful ones (in submission order) and the
+// joined error (plus cancellation cause, if any) to the checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllRWithTimeout[T, R any](ctx context.Context, state *T, timeout time.Duration, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	results, _, err := parallelR[T, R](ctx, state, f...)
+	checker(results, causeErr(ctx, err))
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithTimeoutR[int, int] = AllRWithTimeout[int, int]
+
+// AllRWithDeadline runs the given functions in parallel with a deadline,
+// passing the results of the successful ones (in submission order) and the
+// joined error (plus cancellation cause, if any) to the checker.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AllRWithDeadline[T, R any](ctx context.Context, state *T, deadline time.Time, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	results, _, err := parallelR[T, R](ctx, state, f...)
+	checker(results, causeErr(ctx, err))
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithDeadlineR[int, int] = AllRWithDeadline[int, int]
+
+// AnyR runs the given functions in parallel, canceling the rest with cause
+// ErrAnyWon as soon as one succeeds, but still waits for every Work to
+// return before the checker/cleanUp see state. The checker receives a
+// single-element slice holding the winning result, or nil with the joined
+// error if every Work failed.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyR[T, R any](ctx context.Context, state *T, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	result, err := parallelAnyR[T, R](ctx, state, f...)
+	if err != nil {
+		checker(nil, err)
+	} else {
+		checker([]R{result}, nil)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ ScopeR[int, int] = AnyR[int, int]
+
+// AnyRWithCancel runs the given functions in parallel with a cancel
+// function, canceling the rest with cause ErrAnyWon as soon as one
+// succeeds, but still waits for every Work to return before the
+// checker/cleanUp see state.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyRWithCancel[T, R any](ctx context.Context, state *T, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	result, err := parallelAnyR[T, R](ctx, state, f...)
+	if err != nil {
+		checker(nil, causeErr(ctx, err))
+	} else {
+		checker([]R{result}, nil)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithCancelR[int, int] = AnyRWithCancel[int, int]
+
+// AnyRWithTimeout runs the given functions in parallel with a timeout,
+// canceling the rest with cause ErrAnyWon as soon as one succeeds, but
+// still waits for every Work to return before the checker/cleanUp see
+// state.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyRWithTimeout[T, R any](ctx context.Context, state *T, timeout time.Duration, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	result, err := parallelAnyR[T, R](ctx, state, f...)
+	if err != nil {
+		checker(nil, causeErr(ctx, err))
+	} else {
+		checker([]R{result}, nil)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithTimeoutR[int, int] = AnyRWithTimeout[int, int]
+
+// AnyRWithDeadline runs the given functions in parallel with a deadline,
+// canceling the rest with cause ErrAnyWon as soon as one succeeds, but
+// still waits for every Work to return before the checker/cleanUp see
+// state.
+// If cleanUp is not nil, it is called after all functions have been executed.
+func AnyRWithDeadline[T, R any](ctx context.Context, state *T, deadline time.Time, checker CheckerR[R], cleanUp CleanUp[T], f ...Work2[T, R]) {
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	result, err := parallelAnyR[T, R](ctx, state, f...)
+	if err != nil {
+		checker(nil, causeErr(ctx, err))
+	} else {
+		checker([]R{result}, nil)
+	}
+
+	if cleanUp != nil {
+		cleanUp(ctx, state)
+	}
+}
+
+var _ WithDeadlineR[int, int] = AnyRWithDeadline[int, int]
@@ -0,0 +1,72 @@
+package scope
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAnyRWaitsForLosersBeforeCheckerAndCleanup mirrors
+// TestAllNWorksCompleteBeforeCheckerAndCleanup: AnyR must not let the
+// checker/cleanUp see state until every losing Work has actually returned,
+// not just been asked to via ErrAnyWon cancellation.
+func TestAnyRWaitsForLosersBeforeCheckerAndCleanup(t *testing.T) {
+	const totalWorks = 5
+	const losers = totalWorks - 1
+
+	var completed atomic.Int64
+	works := make([]Work2[int, int], totalWorks)
+	works[0] = func(ctx context.Context, state *int) (int, error) {
+		return 0, nil
+	}
+	for i := 1; i < totalWorks; i++ {
+		works[i] = func(ctx context.Context, state *int) (int, error) {
+			<-ctx.Done()
+			time.Sleep(5 * time.Millisecond)
+			completed.Add(1)
+			return 0, ctx.Err()
+		}
+	}
+
+	checkerRan, cleanupRan := false, false
+	AnyR(context.Background(), new(int), func(results []int, err error) {
+		checkerRan = true
+		if got := completed.Load(); got != losers {
+			t.Errorf("checker ran with only %d/%d losers complete", got, losers)
+		}
+	}, func(ctx context.Context, state *int) {
+		cleanupRan = true
+		if got := completed.Load(); got != losers {
+			t.Errorf("cleanup ran with only %d/%d losers complete", got, losers)
+		}
+	}, works...)
+
+	if !checkerRan || !cleanupRan {
+		t.Fatalf("expected both checker and cleanup to run, checker=%v cleanup=%v", checkerRan, cleanupRan)
+	}
+}
+
+// TestAnyRNoConcurrentStateAccessAfterReturn reproduces the hazard where a
+// losing Work, abandoned without being waited on, could still be writing to
+// state after AnyR (and its cleanUp) had already handed state back to the
+// caller. Run with -race: it must not report a data race.
+func TestAnyRNoConcurrentStateAccessAfterReturn(t *testing.T) {
+	state := new(int)
+
+	works := []Work2[int, int]{
+		func(ctx context.Context, s *int) (int, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, s *int) (int, error) {
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			*s = 99
+			return 0, ctx.Err()
+		},
+	}
+
+	AnyR(context.Background(), state, func(results []int, err error) {}, nil, works...)
+
+	*state = 1
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,6 +20,40 @@ type (
 	WithDeadline[T any] func(ctx context.Context, state *T, deadline time.Time, checker Checker, cleanup CleanUp[T], works ...Work[T])
 )
 
+// CancelFn cancels the scope that created it with cause, the same way a
+// context.CancelCauseFunc does. Retrieve the one stashed in a running scope's
+// context with Cancel.
+type CancelFn func(cause error)
+
+type cancelFnKey struct{}
+
+// Cancel looks up the CancelFn that SequenceWithCancel, AllWithCancel,
+// AnyWithCancel (or their WithTimeout/WithDeadline siblings) stashed in ctx and
+// invokes it with cause. A Work can call this to fail its sibling Works with a
+// domain error, which the scope's Checker then receives via context.Cause
+// instead of the generic context.Canceled/context.DeadlineExceeded. It is a
+// no-op if ctx was not built by one of those scopes.
+func Cancel(ctx context.Context, cause error) {
+	if fn, ok := ctx.Value(cancelFnKey{}).(CancelFn); ok {
+		fn(cause)
+	}
+}
+
+func withCancelFn(ctx context.Context, cancel context.CancelCauseFunc) context.Context {
+	return context.WithValue(ctx, cancelFnKey{}, CancelFn(cancel))
+}
+
+// causeErr joins err with ctx's cancellation cause, if any, so a scope's
+// Checker sees the original reason the scope stopped rather than just the
+// errors its Works returned.
+func causeErr(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return errors.Join(err, cause)
+	}
+
+	return err
+}
+
 // sequence runs the given functions in sequence.
 // If any of the functions returns an error, the error is returned.
 // If any of the functions panics, the panic is caught and returned as an error.
@@ -49,9 +85,14 @@ func parallel[T any](ctx context.Context, state *T, f ...Work[T]) (success int,
 		}
 	}()
 
+	var wg sync.WaitGroup
+	var successCount atomic.Int64
 	errs := make([]error, len(f))
+
+	wg.Add(len(f))
 	for i, w := range f {
 		go func(i int, w Work[T]) {
+			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
 					errs[i] = fmt.Errorf("scope recovered: %v", r)
@@ -60,11 +101,15 @@ func parallel[T any](ctx context.Context, state *T, f ...Work[T]) (success int,
 
 			if err := w(ctx, state); err != nil {
 				errs[i] = err
+				return
 			}
+
+			successCount.Add(1)
 		}(i, w)
 	}
+	wg.Wait()
 
-	nilIdx := make([]int, 4)
+	nilIdx := make([]int, 0, len(errs))
 	for i, err := range errs {
 		if err == nil {
 			nilIdx = append(nilIdx, i)
@@ -72,7 +117,7 @@ func parallel[T any](ctx context.Context, state *T, f ...Work[T]) (success int,
 	}
 
 	slices.Reverse(nilIdx)
-	success = len(nilIdx)
+	success = int(successCount.Load())
 
 	for _, i := range nilIdx {
 		errs = append(errs[:i], errs[i+1:]...)
@@ -100,10 +145,11 @@ var _ Scope[int] = Sequence[int]
 // If any of the functions returns an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func SequenceWithCancel[T any](ctx context.Context, state *T, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
 
-	if err := sequence[T](ctx, state, f...); err != nil {
+	if err := causeErr(ctx, sequence[T](ctx, state, f...)); err != nil {
 		errChecker(err)
 	}
 
@@ -118,10 +164,14 @@ var _ WithCancel[int] = SequenceWithCancel[int]
 // If any of the functions returns an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func SequenceWithTimeout[T any](ctx context.Context, state *T, timeout time.Duration, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
 
-	if err := sequence[T](ctx, state, f...); err != nil {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	if err := causeErr(ctx, sequence[T](ctx, state, f...)); err != nil {
 		errChecker(err)
 	}
 
@@ -136,10 +186,14 @@ var _ WithTimeout[int] = SequenceWithTimeout[int]
 // If any of the functions returns an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func SequenceWithDeadline[T any](ctx context.Context, state *T, deadline time.Time, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithDeadline(ctx, deadline)
-	defer cancel()
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
 
-	if err := sequence[T](ctx, state, f...); err != nil {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	if err := causeErr(ctx, sequence[T](ctx, state, f...)); err != nil {
 		errChecker(err)
 	}
 
@@ -169,10 +223,12 @@ var _ Scope[int] = All[int]
 // If all functions return an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func AllWithCancel[T any](ctx context.Context, state *T, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
 
-	if _, err := parallel[T](ctx, state, f...); err != nil {
+	_, err := parallel[T](ctx, state, f...)
+	if err := causeErr(ctx, err); err != nil {
 		errChecker(err)
 	}
 
@@ -187,10 +243,15 @@ var _ WithCancel[int] = AllWithCancel[int]
 // If all functions return an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func AllWithTimeout[T any](ctx context.Context, state *T, timeout time.Duration, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
 
-	if _, err := parallel[T](ctx, state, f...); err != nil {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	_, err := parallel[T](ctx, state, f...)
+	if err := causeErr(ctx, err); err != nil {
 		errChecker(err)
 	}
 
@@ -205,10 +266,15 @@ var _ WithTimeout[int] = AllWithTimeout[int]
 // If all functions return an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func AllWithDeadline[T any](ctx context.Context, state *T, deadline time.Time, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithDeadline(ctx, deadline)
-	defer cancel()
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
 
-	if _, err := parallel[T](ctx, state, f...); err != nil {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
+
+	_, err := parallel[T](ctx, state, f...)
+	if err := causeErr(ctx, err); err != nil {
 		errChecker(err)
 	}
 
@@ -239,12 +305,13 @@ var _ Scope[int] = Any[int]
 // If any of the functions returns an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func AnyWithCancel[T any](ctx context.Context, state *T, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
 
 	success, err := parallel[T](ctx, state, f...)
 	if success == 0 {
-		errChecker(err)
+		errChecker(causeErr(ctx, err))
 	}
 
 	if cleanUp != nil {
@@ -258,12 +325,16 @@ var _ WithCancel[int] = AnyWithCancel[int]
 // If any of the functions returns an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func AnyWithTimeout[T any](ctx context.Context, state *T, timeout time.Duration, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, nil)
+	defer cancelTimeout()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
 
 	success, err := parallel[T](ctx, state, f...)
 	if success == 0 {
-		errChecker(err)
+		errChecker(causeErr(ctx, err))
 	}
 
 	if cleanUp != nil {
@@ -277,12 +348,16 @@ var _ WithTimeout[int] = AnyWithTimeout[int]
 // If any of the functions returns an error, the error is passed to the errChecker.
 // If cleanUp is not nil, it is called after all functions have been executed.
 func AnyWithDeadline[T any](ctx context.Context, state *T, deadline time.Time, errChecker Checker, cleanUp CleanUp[T], f ...Work[T]) {
-	ctx, cancel := context.WithDeadline(ctx, deadline)
-	defer cancel()
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, deadline, nil)
+	defer cancelDeadline()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ctx = withCancelFn(ctx, cancel)
 
 	success, err := parallel[T](ctx, state, f...)
 	if success == 0 {
-		errChecker(err)
+		errChecker(causeErr(ctx, err))
 	}
 
 	if cleanUp != nil {
@@ -0,0 +1,141 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Service is one named, independently startable/stoppable component run by
+// Lifecycle/LifecycleWithShutdownTimeout. Start is run once, in declaration
+// order; Stop tears the component back down, in reverse declaration order.
+type Service[T any] struct {
+	Name  string
+	Start Work[T]
+	Stop  CleanUp[T]
+}
+
+var errStopTimeout = errors.New("scope: stop timed out")
+
+// Lifecycle starts each of services in declaration order. If a service's
+// Start errors (fail-fast), the services already started are stopped in
+// reverse order and the joined, per-service-tagged error is passed to
+// checker. Otherwise Lifecycle blocks until ctx.Done(), then stops every
+// service in reverse declaration order — running every Stop even if an
+// earlier one panicked — and passes the joined, per-service-tagged error (if
+// any) to checker.
+// If cleanup is not nil, it is called once every service has stopped.
+func Lifecycle[T any](ctx context.Context, state *T, checker Checker, cleanup CleanUp[T], services ...Service[T]) {
+	LifecycleWithShutdownTimeout(ctx, state, 0, checker, cleanup, services...)
+}
+
+// LifecycleWithShutdownTimeout behaves like Lifecycle, but additionally
+// signals shutdownTimeout to each Stop via its ctx and, if Stop is still
+// running once shutdownTimeout elapses, reports it as having failed with a
+// stop-timeout error tagged by Name. Go gives no way to forcibly abandon a
+// goroutine, so LifecycleWithShutdownTimeout still waits for that Stop to
+// actually return before moving on to the next service or handing state back
+// to checker/cleanup — a Stop that never honors ctx.Done() can still block
+// shutdown past shutdownTimeout. A shutdownTimeout <= 0 disables both the
+// ctx deadline and the timeout reporting.
+func LifecycleWithShutdownTimeout[T any](ctx context.Context, state *T, shutdownTimeout time.Duration, checker Checker, cleanup CleanUp[T], services ...Service[T]) {
+	started := make([]Service[T], 0, len(services))
+
+	for _, svc := range services {
+		if err := startOne(ctx, state, svc); err != nil {
+			errs := append([]error{fmt.Errorf("%s: %w", svc.Name, err)}, stopAll(ctx, state, shutdownTimeout, started)...)
+			checker(errors.Join(errs...))
+
+			if cleanup != nil {
+				cleanup(ctx, state)
+			}
+			return
+		}
+
+		started = append(started, svc)
+	}
+
+	<-ctx.Done()
+
+	if errs := stopAll(ctx, state, shutdownTimeout, started); len(errs) > 0 {
+		checker(errors.Join(errs...))
+	}
+
+	if cleanup != nil {
+		cleanup(ctx, state)
+	}
+}
+
+func startOne[T any](ctx context.Context, state *T, svc Service[T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scope recovered: %v", r)
+		}
+	}()
+
+	return svc.Start(ctx, state)
+}
+
+// stopAll stops started in reverse declaration order, running every Stop
+// even if an earlier one panicked or timed out, and returns one
+// name-tagged error per service whose Stop failed.
+func stopAll[T any](ctx context.Context, state *T, shutdownTimeout time.Duration, started []Service[T]) []error {
+	var errs []error
+
+	for i := len(started) - 1; i >= 0; i-- {
+		svc := started[i]
+		if err := stopOne(ctx, state, shutdownTimeout, svc); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", svc.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// stopOne runs svc.Stop and always waits for it to return before giving the
+// caller state back, even past shutdownTimeout — it only uses shutdownTimeout
+// to (a) tell Stop it's overdue via stopCtx and (b) report a stop-timeout
+// error if Stop was still running once the timeout elapsed. This keeps state
+// from ever being touched by a Stop the caller no longer thinks is running.
+func stopOne[T any](ctx context.Context, state *T, shutdownTimeout time.Duration, svc Service[T]) error {
+	if svc.Stop == nil {
+		return nil
+	}
+
+	stopCtx := ctx
+	var timeout <-chan time.Time
+	if shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		stopCtx, cancel = context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+		timeout = time.After(shutdownTimeout)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("scope recovered: %v", r)
+				return
+			}
+			done <- nil
+		}()
+
+		svc.Stop(stopCtx, state)
+	}()
+
+	timedOut := false
+	for {
+		select {
+		case err := <-done:
+			if timedOut {
+				return errStopTimeout
+			}
+			return err
+		case <-timeout:
+			timedOut = true
+			timeout = nil
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestLifecycleShutdownTimeoutReportsSlowStop checks that a Stop that
+// outlives its shutdownTimeout is reported via the checker as having timed
+// out, tagged by the service's Name.
+func TestLifecycleShutdownTimeoutReportsSlowStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc := Service[int]{
+		Name:  "slow",
+		Start: func(ctx context.Context, state *int) error { return nil },
+		Stop: func(ctx context.Context, state *int) {
+			// Ignores ctx and overruns shutdownTimeout on purpose, so the
+			// timeout branch fires deterministically before Stop returns.
+			time.Sleep(30 * time.Millisecond)
+		},
+	}
+
+	var gotErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		LifecycleWithShutdownTimeout(ctx, new(int), 10*time.Millisecond, func(err error) {
+			gotErr = err
+		}, nil, svc)
+	}()
+
+	cancel()
+	<-done
+
+	if gotErr == nil || !errors.Is(gotErr, errStopTimeout) {
+		t.Fatalf("expected a stop-timeout error, got %v", gotErr)
+	}
+}
+
+// TestLifecycleShutdownTimeoutNoConcurrentStateAccessAfterReturn reproduces
+// the hazard where a Stop abandoned past shutdownTimeout could still be
+// writing to state after LifecycleWithShutdownTimeout (and its cleanup) had
+// already handed state back to the caller. Run with -race: it must not
+// report a data race.
+func TestLifecycleShutdownTimeoutNoConcurrentStateAccessAfterReturn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	state := new(int)
+
+	svc := Service[int]{
+		Name:  "slow",
+		Start: func(ctx context.Context, state *int) error { return nil },
+		Stop: func(ctx context.Context, state *int) {
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			*state = 99
+		},
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	LifecycleWithShutdownTimeout(ctx, state, 10*time.Millisecond, func(err error) {}, nil, svc)
+
+	*state = 1
+}